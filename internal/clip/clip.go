@@ -0,0 +1,327 @@
+// Package clip resolves the `-in` flag into an ordered list of video clips,
+// stages them for bundling (copying, chunking into fMP4 segments, or leaving
+// remote clips as URLs), and renders the manifest.plist every target builder
+// embeds so its generated Swift/tvOS view can read the result back.
+package clip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/LawrenceMarsman/macos-video-screensaver/internal/util"
+)
+
+// Source identifies one input video plus the metadata that ends up in
+// manifest.plist. Title/Crop/Duration are optional hints only set when the
+// caller supplies a JSON manifest. Title falls back to a default derived at
+// bundling time when empty; Crop is left empty rather than defaulted, so the
+// generated playback code can fall back to its own global default instead.
+type Source struct {
+	Path     string   `json:"path"`
+	Title    string   `json:"title,omitempty"`
+	Crop     string   `json:"crop,omitempty"`
+	Duration float64  `json:"duration,omitempty"`
+	Segments []string `json:"-"` // set by StageClips when the clip was chunked into fMP4 segments; Path then holds the segments/NNN directory name
+}
+
+// manifestFile is the top-level shape of a `-in manifest.json` file.
+type manifestFile struct {
+	Clips []Source `json:"clips"`
+}
+
+var clipExtensions = map[string]bool{".mp4": true, ".mov": true, ".m4v": true}
+
+// LoadList resolves the `-in` flag into an ordered list of clips. It accepts
+// a single file, a comma-separated list, a directory (clips are sorted by
+// filename), or a JSON manifest (detected by the .json extension).
+func LoadList(in string) ([]Source, error) {
+	if strings.HasSuffix(strings.ToLower(in), ".json") {
+		return loadManifestFile(in)
+	}
+
+	parts := strings.Split(in, ",")
+	if len(parts) == 1 {
+		if info, err := os.Stat(parts[0]); err == nil && info.IsDir() {
+			return loadDir(parts[0])
+		}
+	}
+
+	var clips []Source
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" { continue }
+		clips = append(clips, Source{Path: p})
+	}
+	if len(clips) == 0 {
+		return nil, errors.New("no input clips specified")
+	}
+	return clips, nil
+}
+
+func loadDir(dir string) ([]Source, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil { return nil, err }
+
+	var clips []Source
+	for _, e := range entries {
+		if e.IsDir() || !clipExtensions[strings.ToLower(filepath.Ext(e.Name()))] { continue }
+		clips = append(clips, Source{Path: filepath.Join(dir, e.Name())})
+	}
+	sort.Slice(clips, func(i, j int) bool { return clips[i].Path < clips[j].Path })
+	if len(clips) == 0 {
+		return nil, fmt.Errorf("no video clips found in %s", dir)
+	}
+	return clips, nil
+}
+
+func loadManifestFile(path string) ([]Source, error) {
+	data, err := os.ReadFile(path)
+	if err != nil { return nil, err }
+
+	var manifest manifestFile
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(manifest.Clips) == 0 {
+		return nil, fmt.Errorf("%s declares no clips", path)
+	}
+	return manifest.Clips, nil
+}
+
+// IsRemoteSource reports whether a clip path is actually a stream URL
+// (http/https/rtsp, including .m3u8 HLS playlists) rather than a local file.
+// Remote clips are embedded by reference instead of being copied into the bundle.
+func IsRemoteSource(p string) bool {
+	return strings.HasPrefix(p, "http://") || strings.HasPrefix(p, "https://") || strings.HasPrefix(p, "rtsp://")
+}
+
+func remoteTitle(rawURL string) string {
+	clean := strings.SplitN(rawURL, "?", 2)[0]
+	base := path.Base(clean)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// preloadIntroClip downloads and trims the first `seconds` of a remote clip
+// into resourcesDir/clips/NNN.intro.mp4 via ffmpeg, so the saver has an
+// embedded fallback to play while the remote stream buffers or is offline.
+// It returns "" (no error) if ffmpeg isn't installed.
+func preloadIntroClip(clipsDir string, index int, sourceURL string, seconds int) (string, error) {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		fmt.Printf("[warn] -preload requested but ffmpeg not found on PATH; skipping intro clip for %s\n", sourceURL)
+		return "", nil
+	}
+	dest := filepath.Join(clipsDir, fmt.Sprintf("%03d.intro.mp4", index))
+	if err := util.Run("", "ffmpeg", "-y", "-i", sourceURL, "-t", fmt.Sprint(seconds), "-c", "copy", dest); err != nil {
+		return "", fmt.Errorf("downloading preload intro: %w", err)
+	}
+	return filepath.Base(dest), nil
+}
+
+// StageClips prepares clips for bundling. Local clips are chunked into fMP4
+// segments under resourcesDir/segments/NNN/ when ffmpeg is on PATH (see
+// transcodeToSegments), falling back to a plain copy into
+// resourcesDir/clips/NNN.ext otherwise. Remote clips (see IsRemoteSource) are
+// left as URLs and streamed at runtime instead. When preloadSeconds > 0, each
+// remote clip gets a locally embedded intro clip spliced in immediately
+// before it, so playback keeps working before the stream buffers or while
+// offline. Returns the staged clips in playback order, ready for ManifestPlist.
+func StageClips(resourcesDir string, clips []Source, preloadSeconds int, segmentDuration float64, targetBitrateKbps int) ([]Source, error) {
+	clipsDir := filepath.Join(resourcesDir, "clips")
+	segmentsDir := filepath.Join(resourcesDir, "segments")
+	if err := os.MkdirAll(clipsDir, 0755); err != nil { return nil, err }
+
+	_, ffmpegErr := exec.LookPath("ffmpeg")
+	ffmpegAvailable := ffmpegErr == nil
+
+	var staged []Source
+	var segmentIndex []segmentIndexEntry
+	for i, c := range clips {
+		if IsRemoteSource(c.Path) {
+			if preloadSeconds > 0 {
+				introFile, err := preloadIntroClip(clipsDir, i, c.Path, preloadSeconds)
+				if err != nil { return nil, err }
+				if introFile != "" {
+					staged = append(staged, Source{
+						Path:  introFile,
+						Title: c.Title + " (intro)",
+						Crop:  c.Crop,
+					})
+				}
+			}
+			remote := c
+			if remote.Title == "" { remote.Title = remoteTitle(c.Path) }
+			staged = append(staged, remote)
+			continue
+		}
+
+		if ffmpegAvailable {
+			segments, initFile, err := transcodeToSegments(segmentsDir, i, c.Path, segmentDuration, targetBitrateKbps)
+			if err != nil { return nil, err }
+			if len(segments) > 0 {
+				local := c
+				local.Path = fmt.Sprintf("%03d", i)
+				local.Segments = segments
+				if local.Title == "" {
+					base := filepath.Base(c.Path)
+					local.Title = strings.TrimSuffix(base, filepath.Ext(base))
+				}
+				staged = append(staged, local)
+				segmentIndex = append(segmentIndex, segmentIndexEntry{
+					Dir: local.Path, Init: initFile, Segments: segments, SegmentDuration: segmentDuration,
+				})
+				continue
+			}
+		}
+
+		ext := filepath.Ext(c.Path)
+		if ext == "" { ext = ".mp4" }
+		dest := filepath.Join(clipsDir, fmt.Sprintf("%03d%s", i, ext))
+		if err := util.CopyFile(c.Path, dest); err != nil {
+			return nil, fmt.Errorf("staging clip %s: %w", c.Path, err)
+		}
+
+		local := c
+		local.Path = filepath.Base(dest)
+		if local.Title == "" {
+			base := filepath.Base(c.Path)
+			local.Title = strings.TrimSuffix(base, filepath.Ext(base))
+		}
+		staged = append(staged, local)
+	}
+
+	if len(segmentIndex) > 0 {
+		if err := os.WriteFile(filepath.Join(segmentsDir, "index.plist"), []byte(segmentIndexPlist(segmentIndex)), 0644); err != nil {
+			return nil, err
+		}
+	}
+	return staged, nil
+}
+
+// segmentIndexEntry describes one clip's chunked segments for segments/index.plist.
+type segmentIndexEntry struct {
+	Dir             string
+	Init            string
+	Segments        []string
+	SegmentDuration float64
+}
+
+// transcodeToSegments re-muxes sourcePath into fragmented MP4 via ffmpeg's
+// fMP4 HLS segmenter: an init.mp4 (moov/ftyp) plus GOP-aligned NNNNNN.m4s
+// media segments under segmentsDir/NNN/, each roughly segmentDuration
+// seconds long and encoded at targetBitrateKbps. The .m3u8 playlist ffmpeg
+// requires to drive the segmenter is discarded; the Swift side reads
+// segments/index.plist instead. Returns the ordered segment filenames and
+// the init segment's filename.
+func transcodeToSegments(segmentsDir string, index int, sourcePath string, segmentDuration float64, targetBitrateKbps int) ([]string, string, error) {
+	clipDir := filepath.Join(segmentsDir, fmt.Sprintf("%03d", index))
+	if err := os.MkdirAll(clipDir, 0755); err != nil { return nil, "", err }
+
+	const initFile = "init.mp4"
+	playlistPath := filepath.Join(clipDir, "stream.m3u8")
+	if err := util.Run("", "ffmpeg", "-y", "-i", sourcePath,
+		"-c:v", "libx264", "-b:v", fmt.Sprintf("%dk", targetBitrateKbps),
+		"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%v)", segmentDuration),
+		"-c:a", "aac",
+		"-f", "hls",
+		"-hls_time", fmt.Sprint(segmentDuration),
+		"-hls_playlist_type", "vod",
+		"-hls_segment_type", "fmp4",
+		"-hls_fmp4_init_filename", initFile,
+		"-hls_segment_filename", filepath.Join(clipDir, "%06d.m4s"),
+		playlistPath); err != nil {
+		return nil, "", fmt.Errorf("transcoding %s to fMP4 segments: %w", sourcePath, err)
+	}
+	os.Remove(playlistPath)
+
+	entries, err := os.ReadDir(clipDir)
+	if err != nil { return nil, "", err }
+	var segments []string
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".m4s") { segments = append(segments, e.Name()) }
+	}
+	sort.Strings(segments)
+	return segments, initFile, nil
+}
+
+// segmentIndexPlist renders segments/index.plist: one dict per chunked clip
+// describing its init segment and ordered media segments, so the generated
+// Swift view can assemble a single playable asset by concatenating them
+// instead of decoding one giant embedded MP4.
+func segmentIndexPlist(entries []segmentIndexEntry) string {
+	var dicts strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&dicts, `    <dict>
+        <key>Dir</key>
+        <string>%s</string>
+        <key>Init</key>
+        <string>%s</string>
+        <key>Segments</key>
+        <array>
+%s        </array>
+        <key>SegmentDuration</key>
+        <real>%v</real>
+    </dict>
+`, util.XMLEscape(e.Dir), util.XMLEscape(e.Init), plistStringArray(e.Segments), e.SegmentDuration)
+	}
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<array>
+` + dicts.String() + `</array>
+</plist>
+`
+}
+
+func plistStringArray(values []string) string {
+	var b strings.Builder
+	for _, v := range values {
+		fmt.Fprintf(&b, "            <string>%s</string>\n", util.XMLEscape(v))
+	}
+	return b.String()
+}
+
+// ManifestPlist renders manifest.plist: an array of dicts (SegmentDir, File,
+// or URL, plus Title, Crop, Duration, Order), one per staged clip, that every
+// target's generated playback code decodes at init. Chunked local clips
+// carry a SegmentDir entry relative to Resources/segments/; plain local
+// clips carry a File entry relative to Resources/clips/; remote clips carry
+// a URL entry instead.
+func ManifestPlist(clips []Source) string {
+	var entries strings.Builder
+	for i, c := range clips {
+		var source string
+		switch {
+		case len(c.Segments) > 0:
+			source = fmt.Sprintf("        <key>SegmentDir</key>\n        <string>segments/%s</string>\n", util.XMLEscape(c.Path))
+		case IsRemoteSource(c.Path):
+			source = fmt.Sprintf("        <key>URL</key>\n        <string>%s</string>\n", util.XMLEscape(c.Path))
+		default:
+			source = fmt.Sprintf("        <key>File</key>\n        <string>clips/%s</string>\n", util.XMLEscape(c.Path))
+		}
+		fmt.Fprintf(&entries, `    <dict>
+%s        <key>Title</key>
+        <string>%s</string>
+        <key>Crop</key>
+        <string>%s</string>
+        <key>Duration</key>
+        <real>%v</real>
+        <key>Order</key>
+        <integer>%d</integer>
+    </dict>
+`, source, util.XMLEscape(c.Title), util.XMLEscape(c.Crop), c.Duration, i)
+	}
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<array>
+` + entries.String() + `</array>
+</plist>
+`
+}
@@ -0,0 +1,89 @@
+// Package util holds the small filesystem/exec/naming helpers shared by every
+// target builder (mac, win, tvos) and by the templates/pkg packages they call
+// into, so none of them has to depend on the CLI's main package.
+package util
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// SanitizeName trims whitespace from a user-supplied screensaver name,
+// falling back to a default when it's empty.
+func SanitizeName(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" { return "Screensaver" }
+	return s
+}
+
+// BundleIdentifier derives the CFBundleIdentifier used both in Info.plist and
+// by the generated Swift code to look up ScreenSaverDefaults(forModuleWithName:).
+func BundleIdentifier(name string) string {
+	return "com.example." + strings.ToLower(strings.ReplaceAll(name, " ", ""))
+}
+
+// xmlReplacer escapes the five characters XML treats specially, so a
+// user-supplied name or filename (e.g. "AT&T Promo.mp4", "Tom & Jerry
+// <clip>") can't produce malformed plist XML that PropertyListSerialization
+// then fails to parse at all.
+var xmlReplacer = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&apos;",
+)
+
+// XMLEscape escapes s for safe interpolation into the <string> contents of a
+// generated plist (or other XML) document.
+func XMLEscape(s string) string { return xmlReplacer.Replace(s) }
+
+// CopyFile copies src to dst, creating dst's parent directory if needed.
+func CopyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil { return err }
+	defer in.Close()
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil { return err }
+	out, err := os.Create(dst)
+	if err != nil { return err }
+	defer out.Close()
+	if _, err := io.Copy(out, in); err != nil { return err }
+	return out.Close()
+}
+
+// CopyDir copies src to dst, recursing if src is a directory and falling
+// back to CopyFile for a single file.
+func CopyDir(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil { return err }
+	if info.IsDir() {
+		return copyDirRecursive(src, dst)
+	}
+	return CopyFile(src, dst)
+}
+
+func copyDirRecursive(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil { return err }
+		rel, _ := filepath.Rel(src, path)
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		return CopyFile(path, target)
+	})
+}
+
+// Run executes name with args in dir (the current directory when dir is
+// empty), streaming its stdout/stderr straight through.
+func Run(dir string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
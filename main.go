@@ -7,9 +7,10 @@
 // - Embeds the MP4 video file in the bundle resources
 // - Supports both preview and full-screen modes
 // - Automatically loops video with muted audio
+// - Exposes a real Configure… sheet backed by ScreenSaverDefaults
 //
 // Usage:
-//   go run main.go mac -in video.mp4 -out MyScreensaver.saver [-name "My Screensaver"]
+//   go run main.go mac -in video.mp4 -out MyScreensaver.saver [-name "My Screensaver"] [-config-schema options.json]
 //
 // Requirements:
 // - macOS with Xcode Command Line Tools
@@ -24,14 +25,18 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"io"
-	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
-	"strings"
 	"time"
+
+	"github.com/LawrenceMarsman/macos-video-screensaver/internal/clip"
+	"github.com/LawrenceMarsman/macos-video-screensaver/internal/util"
+	"github.com/LawrenceMarsman/macos-video-screensaver/pkg/sign"
+	"github.com/LawrenceMarsman/macos-video-screensaver/templates/mac"
+	"github.com/LawrenceMarsman/macos-video-screensaver/templates/tvos"
+	"github.com/LawrenceMarsman/macos-video-screensaver/templates/win"
 )
 
 func main() {
@@ -41,9 +46,19 @@ func main() {
     }
     cmd := os.Args[1]
     flagSet := flag.NewFlagSet(cmd, flag.ExitOnError)
-    in := flagSet.String("in", "", "input MP4 file")
+    in := flagSet.String("in", "", "input clip(s): a single MP4, a comma-separated list, a directory of clips, or a JSON manifest")
     out := flagSet.String("out", "", "output .saver bundle")
     name := flagSet.String("name", "MyScreensaver", "screensaver display name")
+    configSchema := flagSet.String("config-schema", "", "path to a JSON file declaring the Configure… sheet's options (defaults to a built-in schema)")
+    cacheSize := flagSet.Int64("cache-size", 512*1024*1024, "on-disk LRU cache bound, in bytes, for streamed http(s)/rtsp/HLS clips")
+    preload := flagSet.Int("preload", 0, "seconds of a remote clip's start to download and embed as an offline fallback intro (requires ffmpeg)")
+    segmentDuration := flagSet.Float64("segment-duration", 4.0, "target duration, in seconds, of each fMP4 media segment when chunking local clips (requires ffmpeg)")
+    targetBitrate := flagSet.Int("target-bitrate", 6000, "target video bitrate, in kbps, used when chunking local clips into fMP4 segments (requires ffmpeg)")
+    signIdentity := flagSet.String("sign", "", `mac only: codesign identity, e.g. "Developer ID Application: Name (TEAMID)"`)
+    entitlements := flagSet.String("entitlements", "", "mac only: path to an entitlements plist passed to codesign")
+    notarizeProfile := flagSet.String("notarize-profile", "", "mac only: xcrun notarytool keychain profile to submit the signed bundle to")
+    pkgOut := flagSet.String("pkg", "", "mac only: also build a signed .pkg installer at this path")
+    withHelper := flagSet.Bool("with-helper", false, "mac only: also emit a companion LaunchAgent helper app that forwards videosaver:// URL opens to the running saver's control socket")
     if err := flagSet.Parse(os.Args[2:]); err != nil {
         fatal(err)
     }
@@ -52,26 +67,73 @@ func main() {
         os.Exit(2)
     }
 
-    switch cmd {
-    case "mac":
-        if err := buildMacSaver(*in, *out, *name); err != nil {
-            fatal(err)
-        }
-        fmt.Println("âœ… Built macOS screensaver:", *out)
-    default:
+    builder, ok := builders[cmd]
+    if !ok {
         usage()
         os.Exit(2)
     }
+    opts := Options{
+        ConfigSchemaPath:  *configSchema,
+        CacheSizeBytes:    *cacheSize,
+        PreloadSeconds:    *preload,
+        SegmentDuration:   *segmentDuration,
+        TargetBitrateKbps: *targetBitrate,
+        SignIdentity:      *signIdentity,
+        EntitlementsPath:  *entitlements,
+        NotarizeProfile:   *notarizeProfile,
+        PkgOut:            *pkgOut,
+        WithHelper:        *withHelper,
+    }
+    if err := builder.Build(*in, *out, *name, opts); err != nil {
+        fatal(err)
+    }
+    fmt.Printf("âœ… Built %s target: %s\n", cmd, *out)
 }
 
 func usage() {
-    fmt.Println(`macOS Video Screensaver Generator - convert MP4 to macOS .saver bundle
+    fmt.Println(`Video Screensaver Generator - convert MP4 to a native screensaver/app
 
 Usage:
-  go run main.go mac -in video.mp4 -out MyScreensaver.saver [-name "My Screensaver"]
+  go run main.go <mac|win|tvos> -in video.mp4 -out MyScreensaver.saver [-name "My Screensaver"] [-config-schema options.json]
+
+  mac  builds a macOS .saver bundle (ScreenSaverView, via swiftc or Xcode).
+  win  builds a Windows .scr screensaver (Win32 + Media Foundation, via a
+       mingw-w64 cross compiler); the generated source is shipped as-is if
+       no compiler is found.
+  tvos emits an Xcode project for an Apple TV app (AVPlayerViewController +
+       a Top Shelf content provider), built with xcodebuild when available.
+
+  -in accepts a single MP4, a comma-separated list of clips ("a.mp4,b.mp4"),
+  a directory of clips, or a JSON manifest ({"clips": [{"path": "a.mp4"}, ...]}).
+  Multiple clips are bundled into a single output that rotates through them
+  (mac only; win embeds just the first clip, and tvos embeds every clip but
+  plays only the first).
+  A clip path may also be an http(s):// or rtsp:// URL (including .m3u8 HLS
+  playlists); such clips are streamed and cached on disk rather than embedded
+  (mac only).
+  Local clips are transcoded to fragmented-MP4 segments when ffmpeg is on
+  PATH, so long loops ship as small chunks instead of one giant MP4 and loop
+  without the decode hitch of a single huge asset; tune this with
+  -segment-duration and -target-bitrate (mac only).
+  -sign, -entitlements, -notarize-profile, and -pkg (mac only) codesign and
+  notarize the built .saver and optionally wrap it into a signed .pkg
+  installer that drops it into /Library/Screen Savers.
+  -with-helper (mac only) also emits a <Name>Helper.app LaunchAgent that
+  registers the videosaver:// URL scheme (e.g.
+  videosaver://play?file=clip.mp4&seek=30) and forwards opened URLs as JSON
+  commands over a Unix socket to the running saver, which applies them to
+  its AVPlayer; supported commands are play, pause, next, seek, setRate,
+  and loadURL, so the saver can be driven from osascript/Shortcuts or a
+  media key daemon.
 
 Example:
-  go run main.go mac -in sunset.mp4 -out SunsetSaver.saver -name "Beautiful Sunset"`)
+  go run main.go mac -in sunset.mp4 -out SunsetSaver.saver -name "Beautiful Sunset"
+  go run main.go mac -in clip1.mp4,clip2.mp4,clip3.mp4 -out Rotation.saver -name "Rotation"
+  go run main.go mac -in https://example.com/stream.m3u8 -out Live.saver -preload 5
+  go run main.go mac -in sunset.mp4 -out SunsetSaver.saver -sign "Developer ID Application: Acme Inc (TEAMID1234)" \
+    -notarize-profile AC_PROFILE -pkg SunsetSaver.pkg
+  go run main.go win -in sunset.mp4 -out SunsetSaver.scr -name "Beautiful Sunset"
+  go run main.go tvos -in sunset.mp4 -out SunsetTV -name "Beautiful Sunset"`)
 }
 
 func fatal(err error) {
@@ -79,125 +141,215 @@ func fatal(err error) {
     os.Exit(1)
 }
 
-func sanitizeName(s string) string {
-    s = strings.TrimSpace(s)
-    if s == "" { return "Screensaver" }
-    return s
+// ---------------- Target builders ----------------
+
+// Options bundles every flag the generator accepts, regardless of which
+// subcommand is running; a given Builder only reads the fields it cares
+// about. Keeping one flag set shared across subcommands (see main) is
+// simpler than splitting per-target flag sets for a CLI this small.
+type Options struct {
+    ConfigSchemaPath  string
+    CacheSizeBytes    int64
+    PreloadSeconds    int
+    SegmentDuration   float64
+    TargetBitrateKbps int
+    SignIdentity      string
+    EntitlementsPath  string
+    NotarizeProfile   string
+    PkgOut            string
+    WithHelper        bool
+}
+
+// Builder produces one platform's screensaver/app from the same -in/-out/-name
+// flags plus the shared Options. Registered in builders, keyed by subcommand.
+type Builder interface {
+    Build(in, out, name string, opts Options) error
+}
+
+var builders = map[string]Builder{
+    "mac":  macBuilder{},
+    "win":  winBuilder{},
+    "tvos": tvosBuilder{},
 }
 
 // ---------------- macOS (.saver) ----------------
 
-func buildMacSaver(in, out, name string) error {
+type macBuilder struct{}
+
+func (macBuilder) Build(in, out, name string, opts Options) error {
+    if err := buildMacSaver(in, out, name, opts.ConfigSchemaPath, opts.CacheSizeBytes, opts.PreloadSeconds, opts.SegmentDuration, opts.TargetBitrateKbps); err != nil {
+        return err
+    }
+
+    signer := sign.Signer{Identity: opts.SignIdentity, EntitlementsPath: opts.EntitlementsPath, NotarizeProfile: opts.NotarizeProfile}
+    if signer.Identity != "" {
+        if err := signer.Sign(out); err != nil { return err }
+        if err := signer.Notarize(out); err != nil { return err }
+    }
+
+    if opts.PkgOut != "" {
+        bundleID := util.BundleIdentifier(util.SanitizeName(name))
+        if err := sign.BuildPkgInstaller(out, opts.PkgOut, bundleID, signer); err != nil { return err }
+    }
+
+    if opts.WithHelper {
+        if err := buildControlHelper(out, name); err != nil { return err }
+    }
+
+    return nil
+}
+
+func buildMacSaver(in, out, name, configSchemaPath string, cacheSizeBytes int64, preloadSeconds int, segmentDuration float64, targetBitrateKbps int) error {
     if runtime.GOOS != "darwin" {
         fmt.Println("[warn] Building a macOS .saver requires macOS.")
     }
-    
+
+    schema, err := mac.LoadConfigSchema(configSchemaPath)
+    if err != nil {
+        return fmt.Errorf("loading config schema: %w", err)
+    }
+
+    clips, err := clip.LoadList(in)
+    if err != nil {
+        return fmt.Errorf("resolving input clips: %w", err)
+    }
+
     // Try alternative approaches before requiring Xcode
     if _, err := exec.LookPath("swiftc"); err == nil {
-        return buildMacSaverSwift(in, out, name)
+        return buildMacSaverSwift(clips, out, name, schema, cacheSizeBytes, preloadSeconds, segmentDuration, targetBitrateKbps)
     }
-    
+
     // Fallback to Xcode if available
     if _, err := exec.LookPath("xcodebuild"); err == nil {
-        return buildMacSaverXcode(in, out, name)
+        return buildMacSaverXcode(clips, out, name, schema, cacheSizeBytes, preloadSeconds, segmentDuration, targetBitrateKbps)
     }
-    
+
     return errors.New("Neither swiftc nor xcodebuild found. Install Xcode command line tools: xcode-select --install")
 }
 
-func buildMacSaverSwift(in, out, name string) error {
+func buildMacSaverSwift(clips []clip.Source, out, name string, schema *mac.ConfigSchema, cacheSizeBytes int64, preloadSeconds int, segmentDuration float64, targetBitrateKbps int) error {
     fmt.Println("[info] Using Swift compiler directly")
-    
+
     tempDir, err := os.MkdirTemp("", "scrgen-mac-*")
     if err != nil { return err }
     // Don't remove tempDir immediately to allow debugging
     fmt.Printf("[debug] Temp directory: %s\n", tempDir)
 
-    projName := sanitizeName(name)
-    
+    projName := util.SanitizeName(name)
+    bundleID := util.BundleIdentifier(projName)
+
     // Create bundle structure manually
     bundlePath := filepath.Join(tempDir, projName+".saver")
     contentsPath := filepath.Join(bundlePath, "Contents")
     macosPath := filepath.Join(contentsPath, "MacOS")
     resourcesPath := filepath.Join(contentsPath, "Resources")
-    
+
     for _, dir := range []string{bundlePath, contentsPath, macosPath, resourcesPath} {
         if err := os.MkdirAll(dir, 0755); err != nil { return err }
     }
-    
-    // Copy video
-    if err := copyFile(in, filepath.Join(resourcesPath, "payload.mp4")); err != nil {
+
+    // Stage clips under Resources/clips/NNN.ext (or Resources/segments/NNN/
+    // when chunked) and describe them in manifest.plist
+    staged, err := clip.StageClips(resourcesPath, clips, preloadSeconds, segmentDuration, targetBitrateKbps)
+    if err != nil { return err }
+    manifestPath := filepath.Join(resourcesPath, "manifest.plist")
+    if err := os.WriteFile(manifestPath, []byte(clip.ManifestPlist(staged)), 0644); err != nil {
         return err
     }
-    
+
     // Create Info.plist
     plistPath := filepath.Join(contentsPath, "Info.plist")
-    if err := os.WriteFile(plistPath, []byte(infoPlist(projName)), 0644); err != nil {
+    if err := os.WriteFile(plistPath, []byte(mac.InfoPlist(projName)), 0644); err != nil {
         return err
     }
-    
-    // Create Swift source
+
+    // Create Swift sources: the view, its playlist controller, its remote cache, and its Configure… sheet
     swiftPath := filepath.Join(tempDir, "VideoSaver.swift")
-    if err := os.WriteFile(swiftPath, []byte(swiftSaverClass(projName)), 0644); err != nil {
+    if err := os.WriteFile(swiftPath, []byte(mac.SwiftSaverClass(bundleID, schema)), 0644); err != nil {
+        return err
+    }
+    playlistPath := filepath.Join(tempDir, "Playlist.swift")
+    if err := os.WriteFile(playlistPath, []byte(mac.SwiftPlaylist(bundleID)), 0644); err != nil {
+        return err
+    }
+    cachePath := filepath.Join(tempDir, "CachingLoader.swift")
+    if err := os.WriteFile(cachePath, []byte(mac.SwiftCachingLoader(cacheSizeBytes)), 0644); err != nil {
+        return err
+    }
+    configurePath := filepath.Join(tempDir, "ConfigureSheet.swift")
+    if err := os.WriteFile(configurePath, []byte(mac.SwiftConfigureSheet(bundleID, schema)), 0644); err != nil {
         return err
     }
-    
+    controlSocketPath := filepath.Join(tempDir, "ControlSocket.swift")
+    if err := os.WriteFile(controlSocketPath, []byte(mac.SwiftControlSocket(bundleID)), 0644); err != nil {
+        return err
+    }
+
     // Compile with swiftc as a shared library for screensavers
     execPath := filepath.Join(macosPath, "VideoSaver")
     fmt.Printf("[debug] Compiling Swift to: %s\n", execPath)
-    if err := run(tempDir, "swiftc", 
+    if err := util.Run(tempDir, "swiftc",
         "-framework", "ScreenSaver",
-        "-framework", "AVFoundation", 
+        "-framework", "AVFoundation",
         "-framework", "AVKit",
         "-framework", "Cocoa",
         "-emit-library",
         "-module-name", "VideoSaver",
         "-o", execPath,
-        "VideoSaver.swift"); err != nil {
+        "VideoSaver.swift", "Playlist.swift", "CachingLoader.swift", "ConfigureSheet.swift", "ControlSocket.swift"); err != nil {
         return fmt.Errorf("swift compilation failed: %w", err)
     }
-    
+
     // Verify executable was created
     if _, err := os.Stat(execPath); err != nil {
         return fmt.Errorf("executable not created: %s", execPath)
     }
     fmt.Printf("[debug] Executable created successfully: %s\n", execPath)
-    
+
     // Copy bundle to output
-    if err := copyDir(bundlePath, out); err != nil { return err }
-    
+    if err := util.CopyDir(bundlePath, out); err != nil { return err }
+
     // Ensure executable permissions on the final output
     finalExecPath := filepath.Join(out, "Contents/MacOS/VideoSaver")
     if err := os.Chmod(finalExecPath, 0755); err != nil {
         fmt.Printf("[warn] Could not set executable permissions: %v\n", err)
     }
-    
+
     return nil
 }
 
-func buildMacSaverXcode(in, out, name string) error {
+func buildMacSaverXcode(clips []clip.Source, out, name string, schema *mac.ConfigSchema, cacheSizeBytes int64, preloadSeconds int, segmentDuration float64, targetBitrateKbps int) error {
     fmt.Println("[info] Using Xcode build system")
 
     tempDir, err := os.MkdirTemp("", "scrgen-mac-*")
     if err != nil { return err }
     // Do not defer RemoveAll; leave for inspection on failures
 
-    projName := sanitizeName(name)
+    projName := util.SanitizeName(name)
+    bundleID := util.BundleIdentifier(projName)
     swiftFiles := map[string]string{
-        filepath.Join(tempDir, "VideoSaver.xcodeproj/project.pbxproj"): xcodeprojPbxproj(projName),
-        filepath.Join(tempDir, "VideoSaver/VideoSaver.swift"):          swiftSaverClass(projName),
-        filepath.Join(tempDir, "VideoSaver/Info.plist"):               infoPlist(projName),
+        filepath.Join(tempDir, "VideoSaver.xcodeproj/project.pbxproj"): mac.XcodeprojPbxproj(projName),
+        filepath.Join(tempDir, "VideoSaver/VideoSaver.swift"):          mac.SwiftSaverClass(bundleID, schema),
+        filepath.Join(tempDir, "VideoSaver/Playlist.swift"):            mac.SwiftPlaylist(bundleID),
+        filepath.Join(tempDir, "VideoSaver/CachingLoader.swift"):       mac.SwiftCachingLoader(cacheSizeBytes),
+        filepath.Join(tempDir, "VideoSaver/ConfigureSheet.swift"):     mac.SwiftConfigureSheet(bundleID, schema),
+        filepath.Join(tempDir, "VideoSaver/ControlSocket.swift"):      mac.SwiftControlSocket(bundleID),
+        filepath.Join(tempDir, "VideoSaver/Info.plist"):               mac.InfoPlist(projName),
     }
     for p, content := range swiftFiles {
         if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil { return err }
         if err := os.WriteFile(p, []byte(content), 0644); err != nil { return err }
     }
-    // Copy MP4 into the target bundle resources folder (referenced by project file)
-    payloadDest := filepath.Join(tempDir, "VideoSaver/payload.mp4")
-    if err := copyFile(in, payloadDest); err != nil { return err }
+    // Stage clips + manifest into the target bundle resources folder (referenced by project file)
+    staged, err := clip.StageClips(filepath.Join(tempDir, "VideoSaver"), clips, preloadSeconds, segmentDuration, targetBitrateKbps)
+    if err != nil { return err }
+    manifestPath := filepath.Join(tempDir, "VideoSaver/manifest.plist")
+    if err := os.WriteFile(manifestPath, []byte(clip.ManifestPlist(staged)), 0644); err != nil {
+        return err
+    }
 
     // Build .saver
-    if err := run(tempDir, "xcodebuild", "-project", "VideoSaver.xcodeproj", "-scheme", "VideoSaver", "-configuration", "Release", "build"); err != nil {
+    if err := util.Run(tempDir, "xcodebuild", "-project", "VideoSaver.xcodeproj", "-scheme", "VideoSaver", "-configuration", "Release", "build"); err != nil {
         return fmt.Errorf("xcodebuild failed: %w", err)
     }
 
@@ -207,7 +359,7 @@ func buildMacSaverXcode(in, out, name string) error {
         return fmt.Errorf("expected output not found: %s", built)
     }
     // Copy to desired out path
-    if err := copyDir(built, out); err != nil { return err }
+    if err := util.CopyDir(built, out); err != nil { return err }
 
     // Cleanup temp dir after a short delay (to allow inspection if needed)
     go func(dir string) {
@@ -218,252 +370,180 @@ func buildMacSaverXcode(in, out, name string) error {
     return nil
 }
 
-func copyFile(src, dst string) error {
-    in, err := os.Open(src)
-    if err != nil { return err }
-    defer in.Close()
-    if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil { return err }
-    out, err := os.Create(dst)
+// ---------------- Windows (.scr) ----------------
+
+type winBuilder struct{}
+
+// Build compiles a Windows screensaver: a .scr is just a renamed PE
+// executable, so the deliverable is a small Win32 program (WinMain +
+// WndProc) that plays the embedded clip full-screen via Media Foundation.
+// The program does not parse the /s, /c, /p screensaver command-line
+// switches; every invocation starts the same full-screen run.
+// Cross-compiled with mingw-w64 when available; otherwise the generated
+// source is shipped as-is for the caller to build on Windows.
+func (winBuilder) Build(in, out, name string, opts Options) error {
+    clips, err := clip.LoadList(in)
+    if err != nil { return fmt.Errorf("resolving input clips: %w", err) }
+    if len(clips) == 0 { return errors.New("no input clips") }
+    if len(clips) > 1 {
+        fmt.Println("[warn] win target only embeds the first clip; playlist rotation isn't supported yet")
+    }
+    c := clips[0]
+    if clip.IsRemoteSource(c.Path) {
+        return errors.New("win target requires a local MP4; remote URL sources aren't supported yet")
+    }
+
+    tempDir, err := os.MkdirTemp("", "scrgen-win-*")
     if err != nil { return err }
-    defer out.Close()
-    if _, err := io.Copy(out, in); err != nil { return err }
-    return out.Close()
+    fmt.Printf("[debug] Temp directory: %s\n", tempDir)
+
+    projName := util.SanitizeName(name)
+    videoDest := filepath.Join(tempDir, "video.mp4")
+    if err := util.CopyFile(c.Path, videoDest); err != nil {
+        return fmt.Errorf("staging clip %s: %w", c.Path, err)
+    }
+
+    srcPath := filepath.Join(tempDir, "saver.cpp")
+    if err := os.WriteFile(srcPath, []byte(win.SaverSource(projName)), 0644); err != nil { return err }
+
+    if _, err := exec.LookPath("x86_64-w64-mingw32-g++"); err != nil {
+        fmt.Println("[warn] x86_64-w64-mingw32-g++ not found on PATH; shipping generated source instead of a compiled .scr")
+        return util.CopyDir(tempDir, out)
+    }
+
+    exePath := filepath.Join(tempDir, projName+".scr")
+    if err := util.Run(tempDir, "x86_64-w64-mingw32-g++", "saver.cpp",
+        "-o", exePath,
+        "-lmfplay", "-lmfplat", "-lmfreadwrite", "-lmf", "-lmfuuid", "-lole32", "-luuid", "-lgdi32", "-luser32",
+        "-mwindows"); err != nil {
+        return fmt.Errorf("compiling Windows screensaver: %w", err)
+    }
+
+    if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil { return err }
+    if err := util.CopyFile(exePath, out); err != nil { return err }
+    return util.CopyFile(videoDest, filepath.Join(filepath.Dir(out), "video.mp4"))
 }
 
-func copyDir(src, dst string) error {
-    info, err := os.Stat(src)
+// ---------------- tvOS (Xcode project) ----------------
+
+type tvosBuilder struct{}
+
+// Build emits an Xcode project for an Apple TV app: a single
+// AVPlayerViewController-backed scene that loops the embedded clip
+// full-screen, plus a Top Shelf content provider stub compiled into the app
+// target (there is no separate extension target), in the
+// same aerial/Top-Shelf style as Apple's own screensaver apps. Reuses
+// clip.StageClips/clip.ManifestPlist so clip chunking and manifest
+// generation stay identical to the mac target. xcodebuild only validates
+// the project here (tvOS builds still need a real device/simulator
+// destination and signing to run); the project ships either way.
+func (tvosBuilder) Build(in, out, name string, opts Options) error {
+    clips, err := clip.LoadList(in)
+    if err != nil { return fmt.Errorf("resolving input clips: %w", err) }
+
+    tempDir, err := os.MkdirTemp("", "scrgen-tvos-*")
     if err != nil { return err }
-    if info.IsDir() {
-        return copyDirRecursive(src, dst)
+    fmt.Printf("[debug] Temp directory: %s\n", tempDir)
+
+    projName := util.SanitizeName(name)
+    bundleID := util.BundleIdentifier(projName)
+
+    files := map[string]string{
+        filepath.Join(tempDir, projName+".xcodeproj/project.pbxproj"):        tvos.Pbxproj(projName),
+        filepath.Join(tempDir, projName, "AppDelegate.swift"):                tvos.AppDelegate(),
+        filepath.Join(tempDir, projName, "PlayerViewController.swift"):       tvos.PlayerViewController(bundleID),
+        filepath.Join(tempDir, projName, "TopShelfContentProvider.swift"):    tvos.TopShelfContentProvider(projName),
+        filepath.Join(tempDir, projName, "Info.plist"):                      tvos.InfoPlist(projName),
+    }
+    for p, content := range files {
+        if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil { return err }
+        if err := os.WriteFile(p, []byte(content), 0644); err != nil { return err }
+    }
+
+    staged, err := clip.StageClips(filepath.Join(tempDir, projName), clips, opts.PreloadSeconds, opts.SegmentDuration, opts.TargetBitrateKbps)
+    if err != nil { return err }
+    manifestPath := filepath.Join(tempDir, projName, "manifest.plist")
+    if err := os.WriteFile(manifestPath, []byte(clip.ManifestPlist(staged)), 0644); err != nil {
+        return err
     }
-    return copyFile(src, dst)
-}
 
-func copyDirRecursive(src, dst string) error {
-    return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
-        if err != nil { return err }
-        rel, _ := filepath.Rel(src, path)
-        target := filepath.Join(dst, rel)
-        if d.IsDir() {
-            return os.MkdirAll(target, 0755)
+    if _, err := exec.LookPath("xcodebuild"); err == nil {
+        if err := util.Run(tempDir, "xcodebuild", "-project", projName+".xcodeproj", "-scheme", projName, "-destination", "generic/platform=tvOS", "build"); err != nil {
+            fmt.Printf("[warn] xcodebuild validation failed, shipping the project anyway: %v\n", err)
         }
-        return copyFile(path, target)
-    })
-}
+    } else {
+        fmt.Println("[warn] xcodebuild not found; shipping the unbuilt Xcode project")
+    }
 
-func run(dir string, name string, args ...string) error {
-    cmd := exec.Command(name, args...)
-    cmd.Dir = dir
-    cmd.Stdout = os.Stdout
-    cmd.Stderr = os.Stderr
-    return cmd.Run()
+    return util.CopyDir(tempDir, out)
 }
 
-// ---------- Xcode project templates ----------
-
-func xcodeprojPbxproj(name string) string {
-    // Minimal pbxproj for a Screen Saver target named `name`.
-    // To keep this file compact, we embed a pre-made pbxproj zipped and substitute name, but here we
-    // generate a very small one inline.
-    // For brevity and reliability, we use a single target with sources: VideoSaver.swift, Info.plist, payload.mp4
-
-    // This pbxproj is simplified and works with modern Xcode. If Xcode changes formats, you may need to
-    // refresh it. The UUIDs are fixed for simplicity.
-    return strings.ReplaceAll(`// !$*UTF8*$!
-{
-  archiveVersion = 1;
-  classes = {};
-  objectVersion = 56;
-  objects = {
-
-/* Begin PBXFileReference section */
-    000000000000000000000001 /* VideoSaver.swift */ = {isa = PBXFileReference; lastKnownFileType = sourcecode.swift; path = VideoSaver.swift; sourceTree = "<group>"; };
-    000000000000000000000002 /* Info.plist */ = {isa = PBXFileReference; lastKnownFileType = text.plist.xml; path = Info.plist; sourceTree = "<group>"; };
-    000000000000000000000003 /* payload.mp4 */ = {isa = PBXFileReference; lastKnownFileType = file; path = payload.mp4; sourceTree = "<group>"; };
-    000000000000000000000010 /* `+name+`.saver */ = {isa = PBXFileReference; explicitFileType = wrapper.cfbundle; includeInIndex = 0; path = "`+name+`.saver"; sourceTree = BUILT_PRODUCTS_DIR; };
-/* End PBXFileReference section */
-
-/* Begin PBXGroup section */
-    000000000000000000000100 = {isa = PBXGroup; children = (
-            000000000000000000000200 /* VideoSaver */,
-            000000000000000000000300 /* Products */,
-        ); sourceTree = "<group>"; };
-    000000000000000000000200 /* VideoSaver */ = {isa = PBXGroup; children = (
-            000000000000000000000001 /* VideoSaver.swift */,
-            000000000000000000000002 /* Info.plist */,
-            000000000000000000000003 /* payload.mp4 */,
-        ); path = VideoSaver; sourceTree = "<group>"; };
-    000000000000000000000300 /* Products */ = {isa = PBXGroup; children = (
-            000000000000000000000010 /* `+name+`.saver */,
-        ); name = Products; sourceTree = "<group>"; };
-/* End PBXGroup section */
-
-/* Begin PBXNativeTarget section */
-    000000000000000000000400 /* VideoSaver */ = {isa = PBXNativeTarget; buildConfigurationList = 000000000000000000000800 /* Build configuration list for PBXNativeTarget "VideoSaver" */; buildPhases = (
-            000000000000000000000500 /* Sources */,
-            000000000000000000000600 /* Resources */,
-        ); buildRules = ( ); dependencies = ( ); name = VideoSaver; productName = VideoSaver; productReference = 000000000000000000000010 /* `+name+`.saver */; productType = "com.apple.product-type.bundle"; };
-/* End PBXNativeTarget section */
-
-/* Begin PBXProject section */
-    000000000000000000000700 /* Project object */ = {isa = PBXProject; buildConfigurationList = 000000000000000000000900 /* Build configuration list for PBXProject "VideoSaver" */; compatibilityVersion = "Xcode 14.0"; developmentRegion = en; hasScannedForEncodings = 0; knownRegions = (en); mainGroup = 000000000000000000000100; productRefGroup = 000000000000000000000300 /* Products */; projectDirPath = ""; projectRoot = ""; targets = (000000000000000000000400 /* VideoSaver */); };
-/* End PBXProject section */
-
-/* Begin PBXResourcesBuildPhase section */
-    000000000000000000000600 /* Resources */ = {isa = PBXResourcesBuildPhase; files = (
-            000000000000000000000604 /* payload.mp4 in Resources */,
-            000000000000000000000603 /* Info.plist in Resources */,
-        ); };
-/* End PBXResourcesBuildPhase section */
-
-/* Begin PBXSourcesBuildPhase section */
-    000000000000000000000500 /* Sources */ = {isa = PBXSourcesBuildPhase; files = (
-            000000000000000000000501 /* VideoSaver.swift in Sources */,
-        ); };
-/* End PBXSourcesBuildPhase section */
-
-/* Begin PBXBuildFile section */
-    000000000000000000000501 /* VideoSaver.swift in Sources */ = {isa = PBXBuildFile; fileRef = 000000000000000000000001 /* VideoSaver.swift */; };
-    000000000000000000000603 /* Info.plist in Resources */ = {isa = PBXBuildFile; fileRef = 000000000000000000000002 /* Info.plist */; };
-    000000000000000000000604 /* payload.mp4 in Resources */ = {isa = PBXBuildFile; fileRef = 000000000000000000000003 /* payload.mp4 */; };
-/* End PBXBuildFile section */
-
-/* Begin XCBuildConfiguration section */
-    000000000000000000000901 /* Debug */ = {isa = XCBuildConfiguration; buildSettings = {
-        PRODUCT_NAME = "`+name+`";
-        INFOPLIST_FILE = VideoSaver/Info.plist;
-        WRAPPER_EXTENSION = saver;
-        CODE_SIGNING_ALLOWED = NO;
-        CODE_SIGNING_REQUIRED = NO;
-        MACOSX_DEPLOYMENT_TARGET = 11.0;
-        SWIFT_VERSION = 5.0;
-    }; name = Debug; };
-    000000000000000000000902 /* Release */ = {isa = XCBuildConfiguration; buildSettings = {
-        PRODUCT_NAME = "`+name+`";
-        INFOPLIST_FILE = VideoSaver/Info.plist;
-        WRAPPER_EXTENSION = saver;
-        CODE_SIGNING_ALLOWED = NO;
-        CODE_SIGNING_REQUIRED = NO;
-        MACOSX_DEPLOYMENT_TARGET = 11.0;
-        SWIFT_VERSION = 5.0;
-    }; name = Release; };
-/* End XCBuildConfiguration section */
-
-/* Begin XCConfigurationList section */
-    000000000000000000000800 /* Build configuration list for PBXNativeTarget "VideoSaver" */ = {isa = XCConfigurationList; buildConfigurations = (
-            000000000000000000000901 /* Debug */,
-            000000000000000000000902 /* Release */,
-        ); defaultConfigurationIsVisible = 0; defaultConfigurationName = Release; };
-    000000000000000000000900 /* Build configuration list for PBXProject "VideoSaver" */ = {isa = XCConfigurationList; buildConfigurations = (
-            000000000000000000000901 /* Debug */,
-            000000000000000000000902 /* Release */,
-        ); defaultConfigurationIsVisible = 0; defaultConfigurationName = Release; };
-/* End XCConfigurationList section */
-
-  };
-  rootObject = 000000000000000000000700 /* Project object */;
-}
-`, "`+name+`", name)
-}
+// ---------------- Control helper (mac) ----------------
+
+// buildControlHelper emits a small companion "<Name>Helper.app" alongside out
+// that registers the videosaver:// URL scheme and forwards opened URLs to the
+// already-running saver's control socket. Only a launchable .app can receive
+// Launch Services URL-open events, so the scheme lives on the helper's
+// Info.plist rather than the .saver's; a LaunchAgent plist is emitted next to
+// it so the helper can be installed to start at login.
+func buildControlHelper(out, name string) error {
+    fmt.Println("[info] Building control helper app")
+
+    tempDir, err := os.MkdirTemp("", "scrgen-helper-*")
+    if err != nil { return err }
+
+    projName := util.SanitizeName(name)
+    bundleID := util.BundleIdentifier(projName)
+    helperName := projName + "Helper"
+    helperBundleID := bundleID + ".helper"
+
+    bundlePath := filepath.Join(tempDir, helperName+".app")
+    contentsPath := filepath.Join(bundlePath, "Contents")
+    macosPath := filepath.Join(contentsPath, "MacOS")
+    if err := os.MkdirAll(macosPath, 0755); err != nil { return err }
 
-func swiftSaverClass(_ string) string {
-    return `import ScreenSaver
-import AVFoundation
-import Cocoa
-
-@objc(VideoSaverView)
-public class VideoSaverView: ScreenSaverView {
-    var player: AVPlayer?
-    var playerLayer: AVPlayerLayer?
-    
-    public override init?(frame: NSRect, isPreview: Bool) {
-        super.init(frame: frame, isPreview: isPreview)
-        setupPlayer()
-    }
-    
-    required init?(coder: NSCoder) {
-        super.init(coder: coder)
-        setupPlayer()
-    }
-    
-    func setupPlayer() {
-        self.wantsLayer = true
-        self.layer = CALayer()
-        self.layer?.backgroundColor = NSColor.black.cgColor
-        
-        // Try to find the video file
-        guard let url = Bundle(for: type(of: self)).url(forResource: "payload", withExtension: "mp4") else { 
-            return 
+    plistPath := filepath.Join(contentsPath, "Info.plist")
+    if err := os.WriteFile(plistPath, []byte(mac.HelperInfoPlist(helperName, helperBundleID)), 0644); err != nil {
+        return err
+    }
+
+    execPath := filepath.Join(macosPath, helperName)
+    if _, err := exec.LookPath("swiftc"); err == nil {
+        sourcePath := filepath.Join(tempDir, helperName+".swift")
+        if err := os.WriteFile(sourcePath, []byte(mac.SwiftControlHelper(bundleID)), 0644); err != nil {
+            return err
         }
-        
-        let item = AVPlayerItem(url: url)
-        self.player = AVPlayer(playerItem: item)
-        self.player?.isMuted = true
-        
-        self.playerLayer = AVPlayerLayer(player: self.player)
-        self.playerLayer?.videoGravity = .resizeAspectFill
-        self.playerLayer?.frame = self.bounds
-        
-        if let playerLayer = self.playerLayer {
-            self.layer?.addSublayer(playerLayer)
+        if err := util.Run(tempDir, "swiftc",
+            "-framework", "Foundation",
+            "-framework", "AppKit",
+            "-o", execPath,
+            sourcePath); err != nil {
+            return fmt.Errorf("swift compilation failed: %w", err)
         }
-        
-        NotificationCenter.default.addObserver(
-            self, 
-            selector: #selector(loopVideo(_:)), 
-            name: .AVPlayerItemDidPlayToEndTime, 
-            object: item
-        )
-        
-        // Start playback after a brief delay
-        DispatchQueue.main.asyncAfter(deadline: .now() + 0.1) {
-            self.player?.play()
+    } else {
+        fmt.Println("[warn] swiftc not found; shipping helper source uncompiled")
+        sourcePath := filepath.Join(macosPath, helperName+".swift")
+        if err := os.WriteFile(sourcePath, []byte(mac.SwiftControlHelper(bundleID)), 0644); err != nil {
+            return err
         }
     }
-    
-    @objc func loopVideo(_ note: Notification) {
-        self.player?.seek(to: .zero)
-        self.player?.play()
-    }
-    
-    public override func animateOneFrame() {
-        super.animateOneFrame()
-        // Update layer frame if needed
-        if let playerLayer = self.playerLayer {
-            playerLayer.frame = self.bounds
+
+    helperOut := filepath.Join(filepath.Dir(out), helperName+".app")
+    if err := util.CopyDir(bundlePath, helperOut); err != nil { return err }
+    if _, err := os.Stat(execPath); err == nil {
+        if err := os.Chmod(filepath.Join(helperOut, "Contents/MacOS", helperName), 0755); err != nil {
+            fmt.Printf("[warn] Could not set executable permissions: %v\n", err)
         }
     }
-    
-    public override var hasConfigureSheet: Bool { false }
-    public override var configureSheet: NSWindow? { nil }
-}
-`
-}
 
-func infoPlist(name string) string {
-    return `<?xml version="1.0" encoding="UTF-8"?>
-<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
-<plist version="1.0">
-<dict>
-    <key>CFBundleDevelopmentRegion</key>
-    <string>en</string>
-    <key>CFBundleExecutable</key>
-    <string>VideoSaver</string>
-    <key>CFBundleIdentifier</key>
-    <string>com.example.` + strings.ToLower(strings.ReplaceAll(name, " ", "")) + `</string>
-    <key>CFBundleInfoDictionaryVersion</key>
-    <string>6.0</string>
-    <key>CFBundleName</key>
-    <string>` + name + `</string>
-    <key>CFBundlePackageType</key>
-    <string>BNDL</string>
-    <key>CFBundleShortVersionString</key>
-    <string>1.0</string>
-    <key>CFBundleVersion</key>
-    <string>1</string>
-    <key>NSPrincipalClass</key>
-    <string>VideoSaverView</string>
-</dict>
-</plist>
-`
-}
\ No newline at end of file
+    agentLabel := helperBundleID
+    agentPath := filepath.Join(filepath.Dir(out), agentLabel+".plist")
+    agentProgram := filepath.Join(helperOut, "Contents/MacOS", helperName)
+    if err := os.WriteFile(agentPath, []byte(mac.LaunchAgentPlist(agentLabel, agentProgram)), 0644); err != nil {
+        return err
+    }
+
+    fmt.Printf("[info] Built control helper: %s\n", helperOut)
+    fmt.Printf("[info] LaunchAgent plist written to: %s (copy to ~/Library/LaunchAgents to run at login)\n", agentPath)
+    return nil
+}
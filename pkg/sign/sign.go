@@ -0,0 +1,102 @@
+// Package sign codesigns, notarizes, and packages a built macOS .saver
+// bundle, and wraps it into a distribution .pkg installer.
+package sign
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/LawrenceMarsman/macos-video-screensaver/internal/util"
+)
+
+// Signer codesigns, notarizes, and verifies a built .saver bundle. Both the
+// swiftc and xcodebuild paths through the mac builder produce a plain,
+// unsigned bundle; the caller runs the same Signer over the result
+// regardless of which path built it.
+type Signer struct {
+	Identity         string // codesign identity, e.g. "Developer ID Application: Name (TEAMID)"; empty disables signing entirely
+	EntitlementsPath string
+	NotarizeProfile  string // xcrun notarytool keychain profile; empty skips notarization
+}
+
+// Sign runs codesign over bundlePath with the hardened runtime enabled (a
+// notarization requirement) and a secure timestamp, then verifies the
+// result. A no-op when Identity is empty.
+func (s Signer) Sign(bundlePath string) error {
+	if s.Identity == "" { return nil }
+
+	args := []string{"--force", "--deep", "--options", "runtime", "--timestamp", "--sign", s.Identity}
+	if s.EntitlementsPath != "" {
+		args = append(args, "--entitlements", s.EntitlementsPath)
+	}
+	args = append(args, bundlePath)
+	if err := util.Run("", "codesign", args...); err != nil {
+		return fmt.Errorf("codesign: %w", err)
+	}
+
+	if err := util.Run("", "codesign", "--verify", "--deep", "--strict", bundlePath); err != nil {
+		return fmt.Errorf("codesign --verify: %w", err)
+	}
+	fmt.Println("[info] codesign verification passed")
+	return nil
+}
+
+// Notarize zips bundlePath, submits it to Apple via notarytool and waits for
+// a result, then staples the ticket onto bundlePath so Gatekeeper can check
+// it offline. A no-op when NotarizeProfile is empty.
+func (s Signer) Notarize(bundlePath string) error {
+	if s.NotarizeProfile == "" { return nil }
+
+	zipPath := bundlePath + ".zip"
+	if err := util.Run("", "ditto", "-c", "-k", "--keepParent", bundlePath, zipPath); err != nil {
+		return fmt.Errorf("zipping for notarization: %w", err)
+	}
+	defer os.Remove(zipPath)
+
+	if err := util.Run("", "xcrun", "notarytool", "submit", zipPath, "--keychain-profile", s.NotarizeProfile, "--wait"); err != nil {
+		return fmt.Errorf("notarytool submit: %w", err)
+	}
+	if err := util.Run("", "xcrun", "stapler", "staple", bundlePath); err != nil {
+		return fmt.Errorf("stapler staple: %w", err)
+	}
+	fmt.Println("[info] Notarization complete and stapled")
+	return nil
+}
+
+// BuildPkgInstaller wraps bundlePath (already signed, if signer.Identity was
+// set) into a distribution .pkg at pkgOut via pkgbuild + productbuild,
+// installing it to /Library/Screen Savers so it's available to every user on
+// the Mac. The distribution package is signed with the same identity, if any.
+func BuildPkgInstaller(bundlePath, pkgOut, bundleID string, signer Signer) error {
+	tempDir, err := os.MkdirTemp("", "scrgen-pkg-*")
+	if err != nil { return err }
+
+	rootDir := filepath.Join(tempDir, "root", "Library", "Screen Savers")
+	if err := os.MkdirAll(rootDir, 0755); err != nil { return err }
+	if err := util.CopyDir(bundlePath, filepath.Join(rootDir, filepath.Base(bundlePath))); err != nil {
+		return fmt.Errorf("staging bundle for pkgbuild: %w", err)
+	}
+
+	componentPkg := filepath.Join(tempDir, "component.pkg")
+	if err := util.Run("", "pkgbuild",
+		"--root", filepath.Join(tempDir, "root"),
+		"--identifier", bundleID,
+		"--version", "1.0",
+		"--install-location", "/",
+		componentPkg); err != nil {
+		return fmt.Errorf("pkgbuild: %w", err)
+	}
+
+	productArgs := []string{}
+	if signer.Identity != "" {
+		productArgs = append(productArgs, "--sign", signer.Identity)
+	}
+	productArgs = append(productArgs, "--package", componentPkg, pkgOut)
+	if err := util.Run("", "productbuild", productArgs...); err != nil {
+		return fmt.Errorf("productbuild: %w", err)
+	}
+
+	fmt.Println("[info] Built installer package:", pkgOut)
+	return nil
+}
@@ -0,0 +1,296 @@
+// Package tvos renders the tvOS target's Xcode project, app sources, and
+// Info.plist.
+package tvos
+
+import (
+	"strings"
+
+	"github.com/LawrenceMarsman/macos-video-screensaver/internal/util"
+)
+
+// Pbxproj renders a minimal Xcode project for a tvOS app target named `name`,
+// mirroring the mac target's fixed-UUID, single-target approach but for
+// product type com.apple.product-type.application. The Top Shelf provider
+// source is compiled into that one app target; there is no separate app
+// extension target.
+func Pbxproj(name string) string {
+	return strings.ReplaceAll(`// !$*UTF8*$!
+{
+  archiveVersion = 1;
+  classes = {};
+  objectVersion = 56;
+  objects = {
+
+/* Begin PBXFileReference section */
+    100000000000000000000001 /* AppDelegate.swift */ = {isa = PBXFileReference; lastKnownFileType = sourcecode.swift; path = AppDelegate.swift; sourceTree = "<group>"; };
+    100000000000000000000002 /* PlayerViewController.swift */ = {isa = PBXFileReference; lastKnownFileType = sourcecode.swift; path = PlayerViewController.swift; sourceTree = "<group>"; };
+    100000000000000000000003 /* TopShelfContentProvider.swift */ = {isa = PBXFileReference; lastKnownFileType = sourcecode.swift; path = TopShelfContentProvider.swift; sourceTree = "<group>"; };
+    100000000000000000000004 /* Info.plist */ = {isa = PBXFileReference; lastKnownFileType = text.plist.xml; path = Info.plist; sourceTree = "<group>"; };
+    100000000000000000000005 /* manifest.plist */ = {isa = PBXFileReference; lastKnownFileType = text.plist.xml; path = manifest.plist; sourceTree = "<group>"; };
+    100000000000000000000006 /* clips */ = {isa = PBXFileReference; lastKnownFileType = folder; path = clips; sourceTree = "<group>"; };
+    100000000000000000000007 /* segments */ = {isa = PBXFileReference; lastKnownFileType = folder; path = segments; sourceTree = "<group>"; };
+    100000000000000000000010 /* `+name+`.app */ = {isa = PBXFileReference; explicitFileType = wrapper.application; includeInIndex = 0; path = "`+name+`.app"; sourceTree = BUILT_PRODUCTS_DIR; };
+/* End PBXFileReference section */
+
+/* Begin PBXGroup section */
+    100000000000000000000100 = {isa = PBXGroup; children = (
+            100000000000000000000200 /* `+name+` */,
+            100000000000000000000300 /* Products */,
+        ); sourceTree = "<group>"; };
+    100000000000000000000200 /* `+name+` */ = {isa = PBXGroup; children = (
+            100000000000000000000001 /* AppDelegate.swift */,
+            100000000000000000000002 /* PlayerViewController.swift */,
+            100000000000000000000003 /* TopShelfContentProvider.swift */,
+            100000000000000000000004 /* Info.plist */,
+            100000000000000000000005 /* manifest.plist */,
+            100000000000000000000006 /* clips */,
+            100000000000000000000007 /* segments */,
+        ); path = "`+name+`"; sourceTree = "<group>"; };
+    100000000000000000000300 /* Products */ = {isa = PBXGroup; children = (
+            100000000000000000000010 /* `+name+`.app */,
+        ); name = Products; sourceTree = "<group>"; };
+/* End PBXGroup section */
+
+/* Begin PBXNativeTarget section */
+    100000000000000000000400 /* `+name+` */ = {isa = PBXNativeTarget; buildConfigurationList = 100000000000000000000800 /* Build configuration list for PBXNativeTarget "`+name+`" */; buildPhases = (
+            100000000000000000000500 /* Sources */,
+            100000000000000000000600 /* Resources */,
+        ); buildRules = ( ); dependencies = ( ); name = "`+name+`"; productName = "`+name+`"; productReference = 100000000000000000000010 /* `+name+`.app */; productType = "com.apple.product-type.application"; };
+/* End PBXNativeTarget section */
+
+/* Begin PBXProject section */
+    100000000000000000000700 /* Project object */ = {isa = PBXProject; buildConfigurationList = 100000000000000000000900 /* Build configuration list for PBXProject "`+name+`" */; compatibilityVersion = "Xcode 14.0"; developmentRegion = en; hasScannedForEncodings = 0; knownRegions = (en); mainGroup = 100000000000000000000100; productRefGroup = 100000000000000000000300 /* Products */; projectDirPath = ""; projectRoot = ""; targets = (100000000000000000000400 /* `+name+` */); };
+/* End PBXProject section */
+
+/* Begin PBXResourcesBuildPhase section */
+    100000000000000000000600 /* Resources */ = {isa = PBXResourcesBuildPhase; files = (
+            100000000000000000000604 /* clips in Resources */,
+            100000000000000000000605 /* manifest.plist in Resources */,
+            100000000000000000000606 /* segments in Resources */,
+        ); };
+/* End PBXResourcesBuildPhase section */
+
+/* Begin PBXSourcesBuildPhase section */
+    100000000000000000000500 /* Sources */ = {isa = PBXSourcesBuildPhase; files = (
+            100000000000000000000501 /* AppDelegate.swift in Sources */,
+            100000000000000000000502 /* PlayerViewController.swift in Sources */,
+            100000000000000000000503 /* TopShelfContentProvider.swift in Sources */,
+        ); };
+/* End PBXSourcesBuildPhase section */
+
+/* Begin PBXBuildFile section */
+    100000000000000000000501 /* AppDelegate.swift in Sources */ = {isa = PBXBuildFile; fileRef = 100000000000000000000001 /* AppDelegate.swift */; };
+    100000000000000000000502 /* PlayerViewController.swift in Sources */ = {isa = PBXBuildFile; fileRef = 100000000000000000000002 /* PlayerViewController.swift */; };
+    100000000000000000000503 /* TopShelfContentProvider.swift in Sources */ = {isa = PBXBuildFile; fileRef = 100000000000000000000003 /* TopShelfContentProvider.swift */; };
+    100000000000000000000604 /* clips in Resources */ = {isa = PBXBuildFile; fileRef = 100000000000000000000006 /* clips */; };
+    100000000000000000000605 /* manifest.plist in Resources */ = {isa = PBXBuildFile; fileRef = 100000000000000000000005 /* manifest.plist */; };
+    100000000000000000000606 /* segments in Resources */ = {isa = PBXBuildFile; fileRef = 100000000000000000000007 /* segments */; };
+/* End PBXBuildFile section */
+
+/* Begin XCBuildConfiguration section */
+    100000000000000000000901 /* Debug */ = {isa = XCBuildConfiguration; buildSettings = {
+        PRODUCT_NAME = "`+name+`";
+        INFOPLIST_FILE = "`+name+`/Info.plist";
+        SDKROOT = appletvos;
+        TVOS_DEPLOYMENT_TARGET = 15.0;
+        CODE_SIGNING_ALLOWED = NO;
+        CODE_SIGNING_REQUIRED = NO;
+        SWIFT_VERSION = 5.0;
+    }; name = Debug; };
+    100000000000000000000902 /* Release */ = {isa = XCBuildConfiguration; buildSettings = {
+        PRODUCT_NAME = "`+name+`";
+        INFOPLIST_FILE = "`+name+`/Info.plist";
+        SDKROOT = appletvos;
+        TVOS_DEPLOYMENT_TARGET = 15.0;
+        CODE_SIGNING_ALLOWED = NO;
+        CODE_SIGNING_REQUIRED = NO;
+        SWIFT_VERSION = 5.0;
+    }; name = Release; };
+/* End XCBuildConfiguration section */
+
+/* Begin XCConfigurationList section */
+    100000000000000000000800 /* Build configuration list for PBXNativeTarget "`+name+`" */ = {isa = XCConfigurationList; buildConfigurations = (
+            100000000000000000000901 /* Debug */,
+            100000000000000000000902 /* Release */,
+        ); defaultConfigurationIsVisible = 0; defaultConfigurationName = Release; };
+    100000000000000000000900 /* Build configuration list for PBXProject "`+name+`" */ = {isa = XCConfigurationList; buildConfigurations = (
+            100000000000000000000901 /* Debug */,
+            100000000000000000000902 /* Release */,
+        ); defaultConfigurationIsVisible = 0; defaultConfigurationName = Release; };
+/* End XCConfigurationList section */
+
+  };
+  rootObject = 100000000000000000000700 /* Project object */;
+}
+`, "`+name+`", name)
+}
+
+func AppDelegate() string {
+	return `import UIKit
+
+@UIApplicationMain
+class AppDelegate: UIResponder, UIApplicationDelegate {
+    var window: UIWindow?
+
+    func application(_ application: UIApplication, didFinishLaunchingWithOptions launchOptions: [UIApplication.LaunchOptionsKey: Any]?) -> Bool {
+        window = UIWindow(frame: UIScreen.main.bounds)
+        window?.rootViewController = PlayerViewController()
+        window?.makeKeyAndVisible()
+        return true
+    }
+}
+`
+}
+
+// PlayerViewController renders PlayerViewController.swift: an
+// AVPlayerViewController subclass that hides its transport controls and
+// loops the first clip described by manifest.plist, the same manifest format
+// the mac target's PlaylistController reads; later entries are not played.
+// A plain local (File) or remote (URL) clip loops
+// via AVPlayerLooper; a clip chunked into fMP4 segments (see SegmentDir,
+// produced by internal/clip.StageClips whenever ffmpeg is on PATH) is queued
+// segment-by-segment instead, since AVPlayerLooper only loops a single
+// templateItem.
+func PlayerViewController(bundleID string) string {
+	return `import AVKit
+import UIKit
+
+final class PlayerViewController: AVPlayerViewController {
+    private var queuePlayer: AVQueuePlayer?
+    private var looper: AVPlayerLooper?
+    private var segmentDir: URL?
+
+    override func viewDidLoad() {
+        super.viewDidLoad()
+        showsPlaybackControls = false
+
+        guard let manifestURL = Bundle.main.url(forResource: "manifest", withExtension: "plist"),
+              let data = try? Data(contentsOf: manifestURL),
+              let entries = (try? PropertyListSerialization.propertyList(from: data, format: nil)) as? [[String: Any]],
+              let first = entries.first else {
+            return
+        }
+
+        if let segmentDirPath = first["SegmentDir"] as? String,
+           let dirURL = Bundle.main.resourceURL?.appendingPathComponent(segmentDirPath) {
+            segmentDir = dirURL
+            playSegments()
+            return
+        }
+
+        let clipURL: URL
+        if let file = first["File"] as? String, let fileURL = Bundle.main.resourceURL?.appendingPathComponent(file) {
+            clipURL = fileURL
+        } else if let urlString = first["URL"] as? String, let remoteURL = URL(string: urlString) {
+            clipURL = remoteURL
+        } else {
+            return
+        }
+
+        let item = AVPlayerItem(url: clipURL)
+        let queuePlayer = AVQueuePlayer()
+        looper = AVPlayerLooper(player: queuePlayer, templateItem: item)
+        queuePlayer.isMuted = true
+        queuePlayer.play()
+
+        player = queuePlayer
+        self.queuePlayer = queuePlayer
+    }
+
+    // playSegments queues one AVPlayerItem per fMP4 media segment described
+    // by segments/index.plist, each prefixed with the shared init.mp4
+    // (moov/ftyp) bytes a lone .m4s isn't independently playable without.
+    // Looping re-queues a freshly built set of items once the last one
+    // finishes, since AVPlayerLooper can't loop a multi-item sequence.
+    private func playSegments() {
+        guard let segmentDir = segmentDir,
+              let items = Self.segmentQueueItems(segmentDir: segmentDir),
+              !items.isEmpty else {
+            return
+        }
+
+        NotificationCenter.default.removeObserver(self, name: .AVPlayerItemDidPlayToEndTime, object: nil)
+        let queuePlayer = AVQueuePlayer(items: items)
+        queuePlayer.isMuted = true
+        NotificationCenter.default.addObserver(
+            self, selector: #selector(segmentQueueDidFinish), name: .AVPlayerItemDidPlayToEndTime, object: items.last)
+        queuePlayer.play()
+
+        player = queuePlayer
+        self.queuePlayer = queuePlayer
+    }
+
+    @objc private func segmentQueueDidFinish() {
+        playSegments()
+    }
+
+    private static func segmentQueueItems(segmentDir: URL) -> [AVPlayerItem]? {
+        let indexURL = segmentDir.deletingLastPathComponent().appendingPathComponent("index.plist")
+        guard let data = try? Data(contentsOf: indexURL),
+              let entries = (try? PropertyListSerialization.propertyList(from: data, format: nil)) as? [[String: Any]],
+              let entry = entries.first(where: { ($0["Dir"] as? String) == segmentDir.lastPathComponent }),
+              let initName = entry["Init"] as? String,
+              let segmentNames = entry["Segments"] as? [String],
+              let initData = try? Data(contentsOf: segmentDir.appendingPathComponent(initName)) else {
+            return nil
+        }
+
+        let tempDir = URL(fileURLWithPath: NSTemporaryDirectory()).appendingPathComponent(segmentDir.lastPathComponent + ".segments")
+        try? FileManager.default.createDirectory(at: tempDir, withIntermediateDirectories: true)
+
+        return segmentNames.compactMap { segmentName in
+            let chunkURL = tempDir.appendingPathComponent(segmentName)
+            guard let segmentData = try? Data(contentsOf: segmentDir.appendingPathComponent(segmentName)),
+                  (try? (initData + segmentData).write(to: chunkURL)) != nil else {
+                return nil
+            }
+            return AVPlayerItem(url: chunkURL)
+        }
+    }
+}
+`
+}
+
+// TopShelfContentProvider renders a minimal Top Shelf content provider stub.
+// A real Top Shelf extension needs its own app-extension target and
+// entitlements, which is beyond what this generator assembles today; this
+// gives the project a starting point that compiles as part of the main
+// target instead of a separate extension.
+func TopShelfContentProvider(name string) string {
+	return `import TVServices
+
+class TopShelfContentProvider: TVTopShelfContentProvider {
+    override func loadTopShelfContent(completionHandler: @escaping (TVTopShelfContent?) -> Void) {
+        completionHandler(nil)
+    }
+}
+`
+}
+
+func InfoPlist(name string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+    <key>CFBundleDevelopmentRegion</key>
+    <string>en</string>
+    <key>CFBundleExecutable</key>
+    <string>` + util.XMLEscape(name) + `</string>
+    <key>CFBundleIdentifier</key>
+    <string>` + util.BundleIdentifier(name) + `</string>
+    <key>CFBundleInfoDictionaryVersion</key>
+    <string>6.0</string>
+    <key>CFBundleName</key>
+    <string>` + util.XMLEscape(name) + `</string>
+    <key>CFBundlePackageType</key>
+    <string>APPL</string>
+    <key>CFBundleShortVersionString</key>
+    <string>1.0</string>
+    <key>CFBundleVersion</key>
+    <string>1</string>
+    <key>UILaunchStoryboardName</key>
+    <string></string>
+</dict>
+</plist>
+`
+}
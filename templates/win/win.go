@@ -0,0 +1,87 @@
+// Package win renders the Windows .scr target's Win32 C++ source.
+package win
+
+// SaverSource renders saver.cpp: a minimal Win32 C++ program that runs as a
+// screensaver. Windows invokes a .scr with /s (run full-screen), /c (show a
+// config dialog) or /p <HWND> (preview inside that window); the generated
+// program ignores its command line, so all three start the same full-screen
+// run. Playback reads video.mp4 from the same directory as the executable
+// via Media Foundation's IMFPMediaPlayer, looping on the
+// MFP_EVENT_TYPE_PLAYBACK_ENDED event.
+func SaverSource(name string) string {
+	return `#include <windows.h>
+#include <mfapi.h>
+#include <mfplay.h>
+#include <mferror.h>
+#include <shellapi.h>
+
+#pragma comment(lib, "mfplat.lib")
+#pragma comment(lib, "mf.lib")
+
+static IMFPMediaPlayer *g_player = NULL;
+
+class PlayerCallback : public IMFPMediaPlayerCallback {
+public:
+    STDMETHODIMP_(ULONG) AddRef() { return 1; }
+    STDMETHODIMP_(ULONG) Release() { return 1; }
+    STDMETHODIMP QueryInterface(REFIID riid, void **ppv) {
+        if (riid == IID_IMFPMediaPlayerCallback) { *ppv = this; return S_OK; }
+        return E_NOINTERFACE;
+    }
+    void STDMETHODCALLTYPE OnMediaPlayerEvent(MFP_EVENT_HEADER *header) {
+        if (header->eEventType == MFP_EVENT_TYPE_PLAYBACK_ENDED && g_player) {
+            g_player->SetPosition(MFP_POSITIONTYPE_100NS, NULL);
+            g_player->Play();
+        }
+    }
+};
+static PlayerCallback g_callback;
+
+static LRESULT CALLBACK WndProc(HWND hwnd, UINT msg, WPARAM wparam, LPARAM lparam) {
+    switch (msg) {
+    case WM_DESTROY:
+        PostQuitMessage(0);
+        return 0;
+    case WM_KEYDOWN:
+    case WM_LBUTTONDOWN:
+    case WM_MOUSEMOVE:
+        DestroyWindow(hwnd);
+        return 0;
+    }
+    return DefWindowProc(hwnd, msg, wparam, lparam);
+}
+
+static wchar_t g_videoPath[MAX_PATH];
+
+int WINAPI WinMain(HINSTANCE hInstance, HINSTANCE hPrevInstance, LPSTR lpCmdLine, int nCmdShow) {
+    GetModuleFileNameW(NULL, g_videoPath, MAX_PATH);
+    wchar_t *slash = wcsrchr(g_videoPath, L'\\');
+    if (slash) *(slash + 1) = 0;
+    wcscat_s(g_videoPath, MAX_PATH, L"video.mp4");
+
+    WNDCLASSW wc = {0};
+    wc.lpfnWndProc = WndProc;
+    wc.hInstance = hInstance;
+    wc.lpszClassName = L"` + name + `ScreensaverWindow";
+    RegisterClassW(&wc);
+
+    HWND hwnd = CreateWindowExW(WS_EX_TOPMOST, wc.lpszClassName, L"` + name + `",
+        WS_POPUP | WS_VISIBLE, 0, 0, GetSystemMetrics(SM_CXSCREEN), GetSystemMetrics(SM_CYSCREEN),
+        NULL, NULL, hInstance, NULL);
+    ShowCursor(FALSE);
+
+    MFStartup(MF_VERSION, MFSTARTUP_FULL);
+    MFPCreateMediaPlayer(g_videoPath, TRUE, 0, &g_callback, hwnd, &g_player);
+
+    MSG msg;
+    while (GetMessageW(&msg, NULL, 0, 0)) {
+        TranslateMessage(&msg);
+        DispatchMessageW(&msg);
+    }
+
+    if (g_player) { g_player->Release(); }
+    MFShutdown();
+    return (int)msg.wParam;
+}
+`
+}